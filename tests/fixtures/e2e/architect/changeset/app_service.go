@@ -0,0 +1,70 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"nitpik/cache"
+	"nitpik/config"
+	"nitpik/httpclient"
+	"nitpik/mailer"
+	"nitpik/webhook"
+)
+
+// NewAppService wires up an AppService and its subsystems from cfg: the
+// user cache (memory or Redis, per cfg.Cache.Backend), the SMTP mailer, the
+// notifier, and the webhook dispatcher with its default handlers
+// registered.
+func NewAppService(cfg *config.Config, db *Database, logger *log.Logger) *AppService {
+	users := &UserService{
+		DB:       db,
+		Cache:    newUserCache(cfg.Cache),
+		CacheTTL: time.Duration(cfg.Cache.TTL),
+		Logger:   logger,
+	}
+
+	m := mailer.NewSMTPMailer(mailer.Mail{
+		Host:               cfg.Mail.Host,
+		Port:               cfg.Mail.Port,
+		Username:           cfg.Mail.Username,
+		Password:           cfg.Mail.Password,
+		From:               cfg.Mail.From,
+		InsecureSkipVerify: cfg.Mail.InsecureSkipVerify,
+	})
+
+	svc := &AppService{
+		DB:       db,
+		Users:    users,
+		Mailer:   m,
+		Notifier: NewNotifier(users, m),
+		HTTP:     httpclient.New(time.Duration(cfg.Report.RequestTimeout), cfg.Report.MaxRetries+1),
+		Logger:   logger,
+		Config:   cfg,
+	}
+
+	dispatcher := webhook.NewDispatcher([]byte(cfg.Webhook.Secret))
+	RegisterWebhookHandlers(dispatcher, svc)
+	svc.Webhooks = dispatcher
+
+	return svc
+}
+
+// newUserCache builds the cache.Cache described by cfg, defaulting to an
+// in-process memory cache. For the memory backend, cfg.TTL also sets how
+// often the janitor sweeps for expired entries, since there's no point
+// reaping more often than entries can expire.
+func newUserCache(cfg config.CacheConfig) cache.Cache {
+	switch cfg.Backend {
+	case "redis":
+		client := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+		return cache.NewRedisCache(client)
+	default:
+		cleanupInterval := time.Duration(cfg.TTL)
+		if cleanupInterval <= 0 {
+			cleanupInterval = time.Minute
+		}
+		return cache.NewMemoryCache(0, cleanupInterval)
+	}
+}
@@ -0,0 +1,20 @@
+// Package cache defines a small key-value cache abstraction with expiry,
+// plus in-memory and Redis-backed implementations.
+package cache
+
+import "time"
+
+// Cache stores byte-encoded values under a key for a bounded amount of
+// time. Callers are responsible for encoding/decoding their own values
+// (e.g. via encoding/json), so the same bytes round-trip identically
+// regardless of which backend is in use.
+type Cache interface {
+	// Get returns the value stored under key, and whether it was found
+	// (and not expired).
+	Get(key string) ([]byte, bool)
+	// Set stores val under key for the given ttl. A ttl of zero means the
+	// entry never expires on its own.
+	Set(key string, val []byte, ttl time.Duration)
+	// Delete removes key, if present.
+	Delete(key string)
+}
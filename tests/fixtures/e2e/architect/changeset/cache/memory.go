@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// entry is a single cached value alongside its expiry and its node in the
+// LRU list.
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+	elem      *list.Element
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// MemoryCache is a concurrency-safe, in-process Cache. It enforces a max
+// number of entries via LRU eviction and runs a background janitor
+// goroutine that reaps expired entries.
+type MemoryCache struct {
+	mu         sync.RWMutex
+	entries    map[string]*entry
+	order      *list.List // front = most recently used
+	maxEntries int
+
+	stop chan struct{}
+}
+
+// NewMemoryCache builds a MemoryCache holding at most maxEntries items
+// (0 means unbounded), reaping expired entries every cleanupInterval.
+func NewMemoryCache(maxEntries int, cleanupInterval time.Duration) *MemoryCache {
+	c := &MemoryCache{
+		entries:    make(map[string]*entry),
+		order:      list.New(),
+		maxEntries: maxEntries,
+		stop:       make(chan struct{}),
+	}
+
+	if cleanupInterval > 0 {
+		go c.janitor(cleanupInterval)
+	}
+
+	return c
+}
+
+// Close stops the background janitor goroutine.
+func (c *MemoryCache) Close() {
+	close(c.stop)
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if e.expired(time.Now()) {
+		c.removeLocked(e)
+		return nil, false
+	}
+
+	c.order.MoveToFront(e.elem)
+	return e.value, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, val []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if e, ok := c.entries[key]; ok {
+		e.value = val
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(e.elem)
+		return
+	}
+
+	e := &entry{key: key, value: val, expiresAt: expiresAt}
+	e.elem = c.order.PushFront(e)
+	c.entries[key] = e
+
+	c.evictIfFullLocked()
+}
+
+// Delete implements Cache.
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.removeLocked(e)
+	}
+}
+
+func (c *MemoryCache) evictIfFullLocked() {
+	if c.maxEntries <= 0 {
+		return
+	}
+	for len(c.entries) > c.maxEntries {
+		oldest := c.order.Back()
+		if oldest == nil {
+			return
+		}
+		c.removeLocked(oldest.Value.(*entry))
+	}
+}
+
+func (c *MemoryCache) removeLocked(e *entry) {
+	c.order.Remove(e.elem)
+	delete(c.entries, e.key)
+}
+
+// janitor periodically evicts expired entries until Close is called.
+func (c *MemoryCache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.reapExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *MemoryCache) reapExpired() {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, e := range c.entries {
+		if e.expired(now) {
+			c.removeLocked(e)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheGetSet(t *testing.T) {
+	c := NewMemoryCache(0, 0)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("expected miss for unset key")
+	}
+
+	c.Set("a", []byte("1"), 0)
+	v, ok := c.Get("a")
+	if !ok || string(v) != "1" {
+		t.Fatalf("expected hit with value %q, got %q, %v", "1", v, ok)
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	c := NewMemoryCache(0, 0)
+
+	c.Set("a", []byte("1"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected expired entry to miss")
+	}
+}
+
+func TestMemoryCacheDelete(t *testing.T) {
+	c := NewMemoryCache(0, 0)
+
+	c.Set("a", []byte("1"), 0)
+	c.Delete("a")
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected deleted entry to miss")
+	}
+}
+
+func TestMemoryCacheEvictsLRU(t *testing.T) {
+	c := NewMemoryCache(2, 0)
+
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+	c.Get("a") // touch a so b becomes the least recently used
+	c.Set("c", []byte("3"), 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected least recently used entry to be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected recently used entry to survive eviction")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("expected newly set entry to be present")
+	}
+}
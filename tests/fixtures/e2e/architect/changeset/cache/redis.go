@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache is a Cache backed by Redis, for deployments that need the
+// cache shared across multiple instances of the application.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache builds a RedisCache using an already-configured client.
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// Get implements Cache. The bytes returned are exactly what was passed to
+// Set, so callers decode them the same way regardless of which Cache
+// implementation is active.
+func (c *RedisCache) Get(key string) ([]byte, bool) {
+	data, err := c.client.Get(context.Background(), key).Bytes()
+	if errors.Is(err, redis.Nil) || err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set implements Cache.
+func (c *RedisCache) Set(key string, val []byte, ttl time.Duration) {
+	c.client.Set(context.Background(), key, val, ttl)
+}
+
+// Delete implements Cache.
+func (c *RedisCache) Delete(key string) {
+	c.client.Del(context.Background(), key)
+}
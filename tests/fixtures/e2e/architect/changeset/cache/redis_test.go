@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisCache(t *testing.T) (*RedisCache, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisCache(client), mr
+}
+
+func TestRedisCacheGetSetRoundTripsBytes(t *testing.T) {
+	c, _ := newTestRedisCache(t)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("expected miss for unset key")
+	}
+
+	want := []byte(`{"id":1,"name":"Ada"}`)
+	c.Set("a", want, 0)
+
+	got, ok := c.Get("a")
+	if !ok {
+		t.Fatal("expected hit for set key")
+	}
+	if string(got) != string(want) {
+		t.Fatalf("expected Get to return the exact bytes passed to Set, got %q, want %q", got, want)
+	}
+}
+
+func TestRedisCacheExpiry(t *testing.T) {
+	c, mr := newTestRedisCache(t)
+
+	c.Set("a", []byte("1"), time.Millisecond)
+	mr.FastForward(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected expired entry to miss")
+	}
+}
+
+func TestRedisCacheDelete(t *testing.T) {
+	c, _ := newTestRedisCache(t)
+
+	c.Set("a", []byte("1"), 0)
+	c.Delete("a")
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected deleted entry to miss")
+	}
+}
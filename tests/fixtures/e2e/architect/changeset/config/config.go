@@ -0,0 +1,173 @@
+// Package config defines the application's typed configuration and how to
+// load it from a file, with env-var overrides for deployment secrets.
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServerConfig controls the application's own HTTP listener.
+type ServerConfig struct {
+	Host string `json:"host" yaml:"host"`
+	Port int    `json:"port" yaml:"port"`
+}
+
+// MailConfig configures the SMTP relay used to send outgoing mail.
+type MailConfig struct {
+	Host               string `json:"host" yaml:"host"`
+	Port               int    `json:"port" yaml:"port"`
+	Username           string `json:"username" yaml:"username"`
+	Password           string `json:"password" yaml:"password"`
+	From               string `json:"from" yaml:"from"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+}
+
+// ReportConfig controls how AppService.GenerateReport talks to the external
+// reporting API.
+type ReportConfig struct {
+	APIBaseURL     string   `json:"api_base_url" yaml:"api_base_url"`
+	RequestTimeout Duration `json:"request_timeout" yaml:"request_timeout"`
+	MaxRetries     int      `json:"max_retries" yaml:"max_retries"`
+}
+
+// CacheConfig selects and configures the UserService cache backend.
+type CacheConfig struct {
+	Backend   string   `json:"backend" yaml:"backend"` // "memory" or "redis"
+	RedisAddr string   `json:"redis_addr" yaml:"redis_addr"`
+	TTL       Duration `json:"ttl" yaml:"ttl"`
+}
+
+// WebhookConfig configures the inbound webhook.Dispatcher exposed by the
+// application's HTTP listener.
+type WebhookConfig struct {
+	// Secret is used to verify the HMAC-SHA256 signature of incoming
+	// webhook requests. Required whenever the application's HTTP listener
+	// (ServerConfig) is enabled, since an unset secret disables signature
+	// verification entirely.
+	Secret string `json:"secret" yaml:"secret"`
+}
+
+// Config is the application's full typed configuration.
+type Config struct {
+	Server  ServerConfig  `json:"server" yaml:"server"`
+	Mail    MailConfig    `json:"mail" yaml:"mail"`
+	Report  ReportConfig  `json:"report" yaml:"report"`
+	Cache   CacheConfig   `json:"cache" yaml:"cache"`
+	Webhook WebhookConfig `json:"webhook" yaml:"webhook"`
+}
+
+// LoadFromFile reads, parses (JSON or YAML, by extension), and validates
+// the config at path, applying env-var overrides for values that
+// deployments commonly inject as secrets.
+func LoadFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read %s: %w", path, err)
+	}
+
+	cfg := &Config{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("config: failed to parse %s as JSON: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("config: failed to parse %s as YAML: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("config: unsupported config file extension %q", ext)
+	}
+
+	applyEnvOverrides(cfg)
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// applyEnvOverrides lets deployments inject values (chiefly secrets) without
+// editing the config file on disk.
+func applyEnvOverrides(cfg *Config) {
+	if v, ok := os.LookupEnv("NITPIK_MAIL_HOST"); ok {
+		cfg.Mail.Host = v
+	}
+	if v, ok := os.LookupEnv("NITPIK_MAIL_PORT"); ok {
+		if port, err := strconv.Atoi(v); err == nil {
+			cfg.Mail.Port = port
+		}
+	}
+	if v, ok := os.LookupEnv("NITPIK_MAIL_USERNAME"); ok {
+		cfg.Mail.Username = v
+	}
+	if v, ok := os.LookupEnv("NITPIK_MAIL_PASSWORD"); ok {
+		cfg.Mail.Password = v
+	}
+	if v, ok := os.LookupEnv("NITPIK_CACHE_REDIS_ADDR"); ok {
+		cfg.Cache.RedisAddr = v
+	}
+	if v, ok := os.LookupEnv("NITPIK_REPORT_API_BASE_URL"); ok {
+		cfg.Report.APIBaseURL = v
+	}
+	if v, ok := os.LookupEnv("NITPIK_WEBHOOK_SECRET"); ok {
+		cfg.Webhook.Secret = v
+	}
+}
+
+// validate returns a descriptive error naming every required field that is
+// missing, so misconfiguration is caught at startup. An unknown
+// cache.backend is reported on its own, without the list of missing fields.
+func (c *Config) validate() error {
+	var missing []string
+
+	if c.Mail.Host == "" {
+		missing = append(missing, "mail.host")
+	}
+	if c.Mail.From == "" {
+		missing = append(missing, "mail.from")
+	}
+	if c.Report.APIBaseURL == "" {
+		missing = append(missing, "report.api_base_url")
+	}
+	if c.Server.Port != 0 && c.Webhook.Secret == "" {
+		missing = append(missing, "webhook.secret")
+	}
+
+	switch c.Cache.Backend {
+	case "", "memory":
+		// memory is the zero-value default; nothing further required.
+	case "redis":
+		if c.Cache.RedisAddr == "" {
+			missing = append(missing, "cache.redis_addr")
+		}
+	default:
+		return fmt.Errorf("config: unknown cache.backend %q (want \"memory\" or \"redis\")", c.Cache.Backend)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("config: missing required fields: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Fingerprint returns a stable hash of the config's current contents, so
+// callers can detect when a reloaded config actually changed.
+func (c *Config) Fingerprint() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
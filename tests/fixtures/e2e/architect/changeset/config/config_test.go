@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTempConfig(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write temp config: %v", err)
+	}
+	return path
+}
+
+func TestLoadFromFileJSON(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{
+		"mail": {"host": "smtp.example.com", "from": "reports@example.com"},
+		"report": {"api_base_url": "https://reports.example.com"}
+	}`)
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile returned error: %v", err)
+	}
+	if cfg.Mail.Host != "smtp.example.com" {
+		t.Fatalf("expected mail.host to be loaded, got %q", cfg.Mail.Host)
+	}
+}
+
+func TestLoadFromFileYAML(t *testing.T) {
+	path := writeTempConfig(t, "config.yaml", "mail:\n  host: smtp.example.com\n  from: reports@example.com\nreport:\n  api_base_url: https://reports.example.com\n")
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile returned error: %v", err)
+	}
+	if cfg.Report.APIBaseURL != "https://reports.example.com" {
+		t.Fatalf("expected report.api_base_url to be loaded, got %q", cfg.Report.APIBaseURL)
+	}
+}
+
+func TestLoadFromFileMissingRequiredFields(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{}`)
+
+	if _, err := LoadFromFile(path); err == nil {
+		t.Fatal("expected error for missing required fields, got nil")
+	}
+}
+
+func TestLoadFromFileRedisBackendRequiresAddr(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{
+		"mail": {"host": "smtp.example.com", "from": "reports@example.com"},
+		"report": {"api_base_url": "https://reports.example.com"},
+		"cache": {"backend": "redis"}
+	}`)
+
+	if _, err := LoadFromFile(path); err == nil {
+		t.Fatal("expected error for redis backend without redis_addr, got nil")
+	}
+}
+
+func TestLoadFromFileServerEnabledRequiresWebhookSecret(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{
+		"mail": {"host": "smtp.example.com", "from": "reports@example.com"},
+		"report": {"api_base_url": "https://reports.example.com"},
+		"server": {"port": 8080}
+	}`)
+
+	if _, err := LoadFromFile(path); err == nil {
+		t.Fatal("expected error for enabled server without webhook.secret, got nil")
+	}
+}
+
+func TestLoadFromFileEnvOverride(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{
+		"mail": {"host": "smtp.example.com", "from": "reports@example.com"},
+		"report": {"api_base_url": "https://reports.example.com"}
+	}`)
+
+	t.Setenv("NITPIK_MAIL_HOST", "smtp.override.com")
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile returned error: %v", err)
+	}
+	if cfg.Mail.Host != "smtp.override.com" {
+		t.Fatalf("expected env override to win, got %q", cfg.Mail.Host)
+	}
+}
+
+func TestFingerprintStableAndSensitiveToChanges(t *testing.T) {
+	a := &Config{Mail: MailConfig{Host: "smtp.example.com"}}
+	b := &Config{Mail: MailConfig{Host: "smtp.example.com"}}
+	c := &Config{Mail: MailConfig{Host: "smtp.other.com"}}
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Fatal("expected identical configs to produce the same fingerprint")
+	}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Fatal("expected different configs to produce different fingerprints")
+	}
+}
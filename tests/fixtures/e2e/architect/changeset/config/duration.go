@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration wraps time.Duration so config files can use human-readable
+// strings like "30s" or "5m" (anything time.ParseDuration accepts), with a
+// bare number still accepted as a count of nanoseconds.
+type Duration time.Duration
+
+// String implements fmt.Stringer.
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalJSON implements json.Marshaler, encoding d as a duration string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("config: invalid duration %q: %w", s, err)
+		}
+		*d = Duration(parsed)
+		return nil
+	}
+
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("config: duration must be a string (e.g. \"30s\") or a number of nanoseconds")
+	}
+	*d = Duration(n)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	// An integer scalar also decodes into a string, so check for it first.
+	if value.ShortTag() == "!!int" {
+		var n int64
+		if err := value.Decode(&n); err != nil {
+			return fmt.Errorf("config: invalid duration %q: %w", value.Value, err)
+		}
+		*d = Duration(n)
+		return nil
+	}
+
+	var s string
+	if err := value.Decode(&s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("config: invalid duration %q: %w", s, err)
+		}
+		*d = Duration(parsed)
+		return nil
+	}
+
+	var n int64
+	if err := value.Decode(&n); err != nil {
+		return fmt.Errorf("config: duration must be a string (e.g. \"30s\") or a number of nanoseconds")
+	}
+	*d = Duration(n)
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadFromFileDurationStringsJSON(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{
+		"mail": {"host": "smtp.example.com", "from": "reports@example.com"},
+		"report": {"api_base_url": "https://reports.example.com", "request_timeout": "30s"},
+		"cache": {"ttl": "5m"}
+	}`)
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile returned error: %v", err)
+	}
+	if time.Duration(cfg.Report.RequestTimeout) != 30*time.Second {
+		t.Fatalf("expected report.request_timeout to be 30s, got %s", cfg.Report.RequestTimeout)
+	}
+	if time.Duration(cfg.Cache.TTL) != 5*time.Minute {
+		t.Fatalf("expected cache.ttl to be 5m, got %s", cfg.Cache.TTL)
+	}
+}
+
+func TestLoadFromFileDurationStringsYAML(t *testing.T) {
+	path := writeTempConfig(t, "config.yaml", "mail:\n  host: smtp.example.com\n  from: reports@example.com\nreport:\n  api_base_url: https://reports.example.com\n  request_timeout: 30s\ncache:\n  ttl: 5m\n")
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile returned error: %v", err)
+	}
+	if time.Duration(cfg.Report.RequestTimeout) != 30*time.Second {
+		t.Fatalf("expected report.request_timeout to be 30s, got %s", cfg.Report.RequestTimeout)
+	}
+	if time.Duration(cfg.Cache.TTL) != 5*time.Minute {
+		t.Fatalf("expected cache.ttl to be 5m, got %s", cfg.Cache.TTL)
+	}
+}
+
+func TestLoadFromFileDurationRawNanoseconds(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{
+		"mail": {"host": "smtp.example.com", "from": "reports@example.com"},
+		"report": {"api_base_url": "https://reports.example.com", "request_timeout": 1000000000}
+	}`)
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile returned error: %v", err)
+	}
+	if time.Duration(cfg.Report.RequestTimeout) != time.Second {
+		t.Fatalf("expected report.request_timeout to be 1s, got %s", cfg.Report.RequestTimeout)
+	}
+}
+
+func TestLoadFromFileDurationInvalidString(t *testing.T) {
+	path := writeTempConfig(t, "config.json", `{
+		"mail": {"host": "smtp.example.com", "from": "reports@example.com"},
+		"report": {"api_base_url": "https://reports.example.com", "request_timeout": "not-a-duration"}
+	}`)
+
+	if _, err := LoadFromFile(path); err == nil {
+		t.Fatal("expected error for invalid duration string, got nil")
+	}
+}
@@ -0,0 +1,20 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+)
+
+// cancelOnClose wraps a response body so that closing it also cancels the
+// per-request timeout context, instead of leaking it until the context
+// deadline passes on its own.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
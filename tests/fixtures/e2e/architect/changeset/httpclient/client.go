@@ -0,0 +1,179 @@
+// Package httpclient wraps *http.Client with per-request timeouts,
+// retries with backoff, Retry-After handling, and JSON decoding.
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Client performs HTTP requests with a per-request timeout and a retry
+// policy for transient failures.
+type Client struct {
+	HTTPClient  *http.Client
+	Timeout     time.Duration
+	MaxAttempts int
+	BaseBackoff time.Duration
+}
+
+// New builds a Client with the given per-request timeout and max retry
+// attempts (a value below 1 is treated as 1, i.e. no retries).
+func New(timeout time.Duration, maxAttempts int) *Client {
+	return &Client{
+		HTTPClient:  &http.Client{},
+		Timeout:     timeout,
+		MaxAttempts: maxAttempts,
+	}
+}
+
+// Get issues a GET request and decodes the JSON response body into dst.
+func (c *Client) Get(ctx context.Context, url string, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, req, dst)
+}
+
+// Do sends req, retrying on network errors and 5xx responses with
+// exponential backoff and jitter, and honoring Retry-After on 429s. The
+// response body is decoded as JSON into dst (skipped if dst is nil).
+// Transport errors are returned as-is; status errors report the HTTP status,
+// and JSON decode errors are wrapped with %w.
+func (c *Client) Do(ctx context.Context, req *http.Request, dst any) error {
+	maxAttempts := c.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		resp, err := c.attempt(ctx, req)
+		if err != nil {
+			lastErr = err
+			if attempt == maxAttempts || ctx.Err() != nil {
+				break
+			}
+			c.sleep(ctx, c.backoff(attempt))
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests:
+			wait := retryAfter(resp.Header.Get("Retry-After"), c.backoff(attempt))
+			resp.Body.Close()
+			lastErr = fmt.Errorf("httpclient: rate limited (status %d)", resp.StatusCode)
+			if attempt == maxAttempts || ctx.Err() != nil {
+				return lastErr
+			}
+			c.sleep(ctx, wait)
+			continue
+
+		case resp.StatusCode >= 500:
+			resp.Body.Close()
+			lastErr = fmt.Errorf("httpclient: server error (status %d)", resp.StatusCode)
+			if attempt == maxAttempts || ctx.Err() != nil {
+				return lastErr
+			}
+			c.sleep(ctx, c.backoff(attempt))
+			continue
+
+		case resp.StatusCode >= 400:
+			resp.Body.Close()
+			return fmt.Errorf("httpclient: unexpected status %d", resp.StatusCode)
+
+		default:
+			defer resp.Body.Close()
+			if dst == nil {
+				return nil
+			}
+			if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+				return fmt.Errorf("httpclient: failed to decode response: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return lastErr
+}
+
+// attempt sends req once, applying the client's per-request timeout. The
+// returned response's body, once closed, releases the timeout's resources.
+func (c *Client) attempt(ctx context.Context, req *http.Request) (*http.Response, error) {
+	reqCtx := ctx
+	var cancel context.CancelFunc
+	if c.Timeout > 0 {
+		reqCtx, cancel = context.WithTimeout(ctx, c.Timeout)
+	}
+
+	r := req.Clone(reqCtx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			if cancel != nil {
+				cancel()
+			}
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	resp, err := c.HTTPClient.Do(r)
+	if err != nil {
+		if cancel != nil {
+			cancel()
+		}
+		return nil, err
+	}
+
+	if cancel != nil {
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	}
+	return resp, nil
+}
+
+// backoff returns the exponential delay before the given retry attempt,
+// with up to 50% jitter to avoid thundering-herd retries.
+func (c *Client) backoff(attempt int) time.Duration {
+	base := c.BaseBackoff
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+
+	d := base * time.Duration(1<<uint(attempt-1))
+	jitter := time.Duration(rand.Int63n(int64(d)/2 + 1))
+	return d + jitter
+}
+
+// sleep waits for d, returning early if ctx is done.
+func (c *Client) sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
+
+// retryAfter parses a Retry-After header (either delay-seconds or an
+// HTTP-date), falling back to fallback if header is empty or unparseable.
+func retryAfter(header string, fallback time.Duration) time.Duration {
+	if header == "" {
+		return fallback
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,127 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoRetriesOn5xxThenSucceeds(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := New(time.Second, 5)
+	c.BaseBackoff = time.Millisecond
+
+	var dst struct {
+		OK bool `json:"ok"`
+	}
+	if err := c.Get(context.Background(), srv.URL, &dst); err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if !dst.OK {
+		t.Fatal("expected decoded response to report ok")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestDoSurfacesStatusErrorAfterExhaustingRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := New(time.Second, 2)
+	c.BaseBackoff = time.Millisecond
+
+	err := c.Get(context.Background(), srv.URL, nil)
+	if err == nil {
+		t.Fatal("expected error after exhausting retries, got nil")
+	}
+}
+
+func TestDoDoesNotRetryOn4xx(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	c := New(time.Second, 5)
+	c.BaseBackoff = time.Millisecond
+
+	if err := c.Get(context.Background(), srv.URL, nil); err == nil {
+		t.Fatal("expected error for 400 response, got nil")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("expected no retries on 4xx, got %d attempts", got)
+	}
+}
+
+func TestDoRespectsRetryAfterHeader(t *testing.T) {
+	var attempts int32
+	var firstAttempt, secondAttempt time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			firstAttempt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		secondAttempt = time.Now()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := New(time.Second, 2)
+
+	if err := c.Get(context.Background(), srv.URL, nil); err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if secondAttempt.Sub(firstAttempt) < time.Second {
+		t.Fatalf("expected retry to wait for Retry-After duration, waited %v", secondAttempt.Sub(firstAttempt))
+	}
+}
+
+func TestDoDecodeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("not json"))
+	}))
+	defer srv.Close()
+
+	c := New(time.Second, 1)
+
+	var dst struct{}
+	err := c.Get(context.Background(), srv.URL, &dst)
+	if err == nil {
+		t.Fatal("expected decode error, got nil")
+	}
+}
+
+func TestRetryAfterParsesSeconds(t *testing.T) {
+	d := retryAfter("2", time.Hour)
+	if d != 2*time.Second {
+		t.Fatalf("expected 2s, got %v", d)
+	}
+}
+
+func TestRetryAfterFallsBackWhenUnparseable(t *testing.T) {
+	d := retryAfter("not-a-duration", 42*time.Millisecond)
+	if d != 42*time.Millisecond {
+		t.Fatalf("expected fallback, got %v", d)
+	}
+}
@@ -0,0 +1,247 @@
+// Package mailer defines the outgoing-message abstraction used by the
+// application and the backends that can fulfil it.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+)
+
+// Mail holds the configuration needed to talk to an SMTP relay.
+type Mail struct {
+	Host               string
+	Port               int
+	Username           string
+	Password           string
+	From               string
+	InsecureSkipVerify bool
+}
+
+// Mailer sends messages on behalf of the application. Swapping the backend
+// (SMTP, a webhook, Slack, ...) behind this interface means callers never
+// need to know which one is active. ctx governs the delivery attempt
+// itself (dialing, the request), so callers can bound or cancel it.
+type Mailer interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+	SendMessage(ctx context.Context, msg Message) error
+}
+
+// SMTPMailer sends email through a configured SMTP relay.
+type SMTPMailer struct {
+	cfg Mail
+}
+
+// NewSMTPMailer builds a Mailer backed by the SMTP relay described by cfg.
+func NewSMTPMailer(cfg Mail) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// SendEmail implements Mailer.
+func (m *SMTPMailer) SendEmail(ctx context.Context, to, subject, body string) error {
+	return m.SendMessage(ctx, Message{To: []string{to}, Subject: subject, TextBody: body})
+}
+
+// SendMessage implements Mailer by rendering msg as an RFC 5322 message and
+// relaying it through SMTP. The connection is dialed with ctx so a
+// cancellation or deadline aborts the dial instead of blocking until the
+// OS-level TCP timeout. STARTTLS is negotiated before authenticating
+// whenever the relay advertises it, per cfg.InsecureSkipVerify.
+func (m *SMTPMailer) SendMessage(ctx context.Context, msg Message) error {
+	data, err := buildMIME(m.cfg.From, msg)
+	if err != nil {
+		return err
+	}
+
+	addr := fmt.Sprintf("%s:%d", m.cfg.Host, m.cfg.Port)
+	recipients := append(append(append([]string{}, msg.To...), msg.Cc...), msg.Bcc...)
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		tlsConfig := &tls.Config{ServerName: m.cfg.Host, InsecureSkipVerify: m.cfg.InsecureSkipVerify}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if ok, _ := client.Extension("AUTH"); ok {
+		auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.cfg.From); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// WebhookMailer delivers messages by POSTing a JSON payload to a URL instead
+// of sending real email. Useful for routing notifications into internal
+// tooling that already speaks webhooks.
+type WebhookMailer struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookMailer builds a Mailer that POSTs to url.
+func NewWebhookMailer(url string) *WebhookMailer {
+	return &WebhookMailer{URL: url, Client: http.DefaultClient}
+}
+
+// webhookAttachment describes an Attachment without its raw bytes, for
+// backends that can't carry a MIME part.
+type webhookAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+}
+
+// webhookPayload flattens a Message into the JSON body posted to a
+// WebhookMailer's URL.
+func webhookPayload(msg Message) any {
+	attachments := make([]webhookAttachment, 0, len(msg.Attachments))
+	for _, a := range msg.Attachments {
+		attachments = append(attachments, webhookAttachment{Filename: a.Filename, ContentType: a.ContentType})
+	}
+
+	return struct {
+		To          []string            `json:"to"`
+		Cc          []string            `json:"cc,omitempty"`
+		Bcc         []string            `json:"bcc,omitempty"`
+		Subject     string              `json:"subject"`
+		TextBody    string              `json:"text_body,omitempty"`
+		HTMLBody    string              `json:"html_body,omitempty"`
+		Attachments []webhookAttachment `json:"attachments,omitempty"`
+	}{
+		To:          msg.To,
+		Cc:          msg.Cc,
+		Bcc:         msg.Bcc,
+		Subject:     msg.Subject,
+		TextBody:    msg.TextBody,
+		HTMLBody:    msg.HTMLBody,
+		Attachments: attachments,
+	}
+}
+
+// SendEmail implements Mailer.
+func (m *WebhookMailer) SendEmail(ctx context.Context, to, subject, body string) error {
+	return m.SendMessage(ctx, Message{To: []string{to}, Subject: subject, TextBody: body})
+}
+
+// SendMessage implements Mailer. Attachments are posted as filename/type
+// metadata only, since the receiving webhook has no notion of a raw email
+// part to carry their bytes.
+func (m *WebhookMailer) SendMessage(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(webhookPayload(msg))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("mailer: webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SlackMailer posts messages to a Slack incoming webhook instead of sending
+// email.
+type SlackMailer struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// NewSlackMailer builds a Mailer that posts to a Slack incoming webhook.
+func NewSlackMailer(webhookURL string) *SlackMailer {
+	return &SlackMailer{WebhookURL: webhookURL, Client: http.DefaultClient}
+}
+
+// SendEmail implements Mailer. The subject and body are flattened into a
+// single Slack message, since Slack has no notion of either.
+func (m *SlackMailer) SendEmail(ctx context.Context, to, subject, body string) error {
+	return m.SendMessage(ctx, Message{To: []string{to}, Subject: subject, TextBody: body})
+}
+
+// SendMessage implements Mailer. The message is flattened into a single
+// Slack message; attachments are listed by filename, since Slack has no
+// notion of a raw email part to carry their bytes.
+func (m *SlackMailer) SendMessage(ctx context.Context, msg Message) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s*\n%s", msg.Subject, msg.TextBody)
+	if len(msg.To) > 0 {
+		fmt.Fprintf(&b, "\n(for %s)", strings.Join(msg.To, ", "))
+	}
+	for _, a := range msg.Attachments {
+		fmt.Fprintf(&b, "\nAttachment: %s", a.Filename)
+	}
+
+	payload, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{b.String()})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("mailer: slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
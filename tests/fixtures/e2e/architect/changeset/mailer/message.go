@@ -0,0 +1,25 @@
+package mailer
+
+import "io"
+
+// Attachment is a single file attached to a Message. Data is read exactly
+// once when the message is built.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        io.Reader
+}
+
+// Message is a full, possibly multipart, email message. Unlike the plain
+// SendEmail call, it supports Cc/Bcc, HTML bodies, custom headers, and
+// attachments.
+type Message struct {
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Subject     string
+	TextBody    string
+	HTMLBody    string
+	Headers     map[string]string
+	Attachments []Attachment
+}
@@ -0,0 +1,130 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+)
+
+// buildMIME renders msg as an RFC 5322 message from the given sender,
+// encoding the text/HTML bodies as quoted-printable and any attachments as
+// base64 parts of a multipart/mixed envelope.
+func buildMIME(from string, msg Message) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	if err := writeBodyPart(mw, msg); err != nil {
+		return nil, err
+	}
+	for _, a := range msg.Attachments {
+		if err := writeAttachmentPart(mw, a); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "From: %s\r\n", from)
+	if len(msg.To) > 0 {
+		fmt.Fprintf(&out, "To: %s\r\n", strings.Join(msg.To, ", "))
+	}
+	if len(msg.Cc) > 0 {
+		fmt.Fprintf(&out, "Cc: %s\r\n", strings.Join(msg.Cc, ", "))
+	}
+	for k, v := range msg.Headers {
+		fmt.Fprintf(&out, "%s: %s\r\n", k, v)
+	}
+	fmt.Fprintf(&out, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	out.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&out, "Content-Type: multipart/mixed; boundary=%q\r\n", mw.Boundary())
+	out.WriteString("\r\n")
+	out.Write(body.Bytes())
+
+	return out.Bytes(), nil
+}
+
+// writeBodyPart writes the text and/or HTML body as a single part, or as a
+// nested multipart/alternative part when both are present.
+func writeBodyPart(mw *multipart.Writer, msg Message) error {
+	switch {
+	case msg.TextBody != "" && msg.HTMLBody != "":
+		var alt bytes.Buffer
+		altWriter := multipart.NewWriter(&alt)
+		if err := writeTextPart(altWriter, "text/plain", msg.TextBody); err != nil {
+			return err
+		}
+		if err := writeTextPart(altWriter, "text/html", msg.HTMLBody); err != nil {
+			return err
+		}
+		if err := altWriter.Close(); err != nil {
+			return err
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", altWriter.Boundary()))
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		_, err = part.Write(alt.Bytes())
+		return err
+
+	case msg.HTMLBody != "":
+		return writeTextPart(mw, "text/html", msg.HTMLBody)
+
+	default:
+		return writeTextPart(mw, "text/plain", msg.TextBody)
+	}
+}
+
+// writeTextPart writes body as a quoted-printable part of the given
+// Content-Type.
+func writeTextPart(mw *multipart.Writer, contentType, body string) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", contentType+"; charset=utf-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// writeAttachmentPart writes a as a base64-encoded part with a
+// Content-Disposition naming its filename.
+func writeAttachmentPart(mw *multipart.Writer, a Attachment) error {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.Filename))
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	enc := base64.NewEncoder(base64.StdEncoding, part)
+	if _, err := io.Copy(enc, a.Data); err != nil {
+		return err
+	}
+	return enc.Close()
+}
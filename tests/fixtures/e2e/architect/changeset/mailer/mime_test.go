@@ -0,0 +1,123 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+)
+
+func TestBuildMIMEParsesAsRFC5322(t *testing.T) {
+	attachmentData := []byte(`{"hello":"world"}`)
+
+	data, err := buildMIME("reports@example.com", Message{
+		To:       []string{"user@example.com"},
+		Subject:  "Your Report",
+		TextBody: "Your report is attached.",
+		HTMLBody: "<p>Your report is attached.</p>",
+		Attachments: []Attachment{{
+			Filename:    "report.json",
+			ContentType: "application/json",
+			Data:        bytes.NewReader(attachmentData),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("buildMIME returned error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("generated message failed to parse as RFC 5322: %v", err)
+	}
+
+	if got := msg.Header.Get("From"); got != "reports@example.com" {
+		t.Fatalf("expected From header, got %q", got)
+	}
+	if got := msg.Header.Get("To"); got != "user@example.com" {
+		t.Fatalf("expected To header, got %q", got)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse Content-Type: %v", err)
+	}
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("expected multipart/mixed, got %q", mediaType)
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+
+	bodyPart, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("failed to read body part: %v", err)
+	}
+	bodyMediaType, _, err := mime.ParseMediaType(bodyPart.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse body part Content-Type: %v", err)
+	}
+	if bodyMediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative body since both bodies were set, got %q", bodyMediaType)
+	}
+
+	attachmentPart, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("failed to read attachment part: %v", err)
+	}
+	if attachmentPart.FileName() != "report.json" {
+		t.Fatalf("expected attachment filename %q, got %q", "report.json", attachmentPart.FileName())
+	}
+
+	raw, err := io.ReadAll(attachmentPart)
+	if err != nil {
+		t.Fatalf("failed to read attachment bytes: %v", err)
+	}
+	got, err := base64.StdEncoding.DecodeString(string(raw))
+	if err != nil {
+		t.Fatalf("attachment part was not valid base64: %v", err)
+	}
+	if !bytes.Equal(got, attachmentData) {
+		t.Fatalf("attachment bytes did not round-trip: got %q, want %q", got, attachmentData)
+	}
+
+	if _, err := mr.NextPart(); err != io.EOF {
+		t.Fatalf("expected exactly two top-level parts, got extra part (err=%v)", err)
+	}
+}
+
+func TestBuildMIMESingleBodyNoAlternative(t *testing.T) {
+	data, err := buildMIME("reports@example.com", Message{
+		To:       []string{"user@example.com"},
+		Subject:  "Plain",
+		TextBody: "just text",
+	})
+	if err != nil {
+		t.Fatalf("buildMIME returned error: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("generated message failed to parse: %v", err)
+	}
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse Content-Type: %v", err)
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	part, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("failed to read body part: %v", err)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse body part Content-Type: %v", err)
+	}
+	if mediaType != "text/plain" {
+		t.Fatalf("expected a plain text/plain part with no attachments, got %q", mediaType)
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"nitpik/mailer"
+)
+
+// Notifier composes a UserService with a Mailer to turn a user ID into a
+// delivered message, independent of AppService.
+type Notifier struct {
+	Users  *UserService
+	Mailer mailer.Mailer
+}
+
+// NewNotifier builds a Notifier from its dependencies.
+func NewNotifier(users *UserService, m mailer.Mailer) *Notifier {
+	return &Notifier{Users: users, Mailer: m}
+}
+
+// NotifyUser looks up the user and sends them an email.
+func (n *Notifier) NotifyUser(ctx context.Context, id int, subject, body string) error {
+	user, err := n.Users.GetUser(id)
+	if err != nil {
+		return err
+	}
+	return n.Mailer.SendEmail(ctx, user.Email, subject, body)
+}
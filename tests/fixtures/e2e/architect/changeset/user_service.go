@@ -0,0 +1,70 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"nitpik/cache"
+)
+
+// defaultUserCacheTTL is used when the deployment's cache.ttl config value
+// is unset (its zero value).
+const defaultUserCacheTTL = 5 * time.Minute
+
+// UserService owns user lookups and the caching/logging around them,
+// extracted out of the former AppService god object.
+type UserService struct {
+	DB       *Database
+	Cache    cache.Cache
+	CacheTTL time.Duration // how long a cached User is kept before it's considered stale; zero means defaultUserCacheTTL
+	Logger   *log.Logger
+}
+
+// cacheTTL returns s.CacheTTL, falling back to defaultUserCacheTTL when unset.
+func (s *UserService) cacheTTL() time.Duration {
+	if s.CacheTTL > 0 {
+		return s.CacheTTL
+	}
+	return defaultUserCacheTTL
+}
+
+// userCacheKey returns the cache key under which a user's record is stored.
+func userCacheKey(id int) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// GetUser fetches a user, caches the result, and logs the access. Cache
+// entries are stored JSON-encoded so every Cache backend round-trips the
+// same bytes; a cache entry that fails to decode is treated as a miss and
+// reloaded from the database.
+func (s *UserService) GetUser(id int) (*User, error) {
+	key := userCacheKey(id)
+	if data, ok := s.Cache.Get(key); ok {
+		var user User
+		if err := json.Unmarshal(data, &user); err == nil {
+			s.Logger.Printf("Cache hit for user %d", id)
+			return &user, nil
+		}
+		s.Logger.Printf("Discarding unreadable cache entry for user %d", id)
+	}
+
+	user, err := s.DB.FindUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(user); err == nil {
+		s.Cache.Set(key, data, s.cacheTTL())
+	}
+	s.Logger.Printf("Loaded user %d from database", id)
+	return user, nil
+}
+
+// InvalidateUser removes any cached record for id. Callers must invoke this
+// after any DB write that changes a user's record (e.g. DB.UpdatePlan,
+// DB.DeleteUser) so GetUser doesn't keep serving stale data.
+func (s *UserService) InvalidateUser(id int) {
+	s.Cache.Delete(userCacheKey(id))
+}
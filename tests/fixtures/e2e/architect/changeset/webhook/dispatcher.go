@@ -0,0 +1,55 @@
+// Package webhook dispatches incoming webhook payloads to strongly-typed
+// handlers registered per event type.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Handler processes the raw JSON payload of a single event type, typically
+// by decoding it via one of the *HandlerFunc adapters in this package. ctx
+// carries the request's deadline/cancellation through to whatever the
+// handler does (database writes, outgoing notifications, ...).
+type Handler func(ctx context.Context, payload []byte) error
+
+// Dispatcher routes webhook payloads to the Handler registered for their
+// event type.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	secret   []byte
+}
+
+// NewDispatcher builds a Dispatcher that verifies incoming requests against
+// secret before dispatching them. A nil or empty secret disables signature
+// verification.
+func NewDispatcher(secret []byte) *Dispatcher {
+	return &Dispatcher{
+		handlers: make(map[string]Handler),
+		secret:   secret,
+	}
+}
+
+// Register associates a Handler with an event type, overwriting any handler
+// previously registered for it.
+func (d *Dispatcher) Register(eventType string, h Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[eventType] = h
+}
+
+// Dispatch routes payload to the handler registered for eventType. It
+// returns an error if no handler is registered or if the handler itself
+// fails.
+func (d *Dispatcher) Dispatch(ctx context.Context, eventType string, payload []byte) error {
+	d.mu.RLock()
+	h, ok := d.handlers[eventType]
+	d.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("webhook: no handler registered for event type %q", eventType)
+	}
+	return h(ctx, payload)
+}
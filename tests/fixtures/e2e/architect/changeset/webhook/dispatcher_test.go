@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestDispatchUnknownEventType(t *testing.T) {
+	d := NewDispatcher(nil)
+
+	err := d.Dispatch(context.Background(), "signup", []byte(`{}`))
+	if err == nil {
+		t.Fatal("expected error for unregistered event type, got nil")
+	}
+}
+
+func TestSignupHandlerFuncMalformedPayload(t *testing.T) {
+	d := NewDispatcher(nil)
+	called := false
+	d.Register("signup", SignupHandlerFunc(func(context.Context, SignupEvent) error {
+		called = true
+		return nil
+	}))
+
+	err := d.Dispatch(context.Background(), "signup", []byte(`not json`))
+	if err == nil {
+		t.Fatal("expected decode error for malformed payload, got nil")
+	}
+	if called {
+		t.Fatal("handler should not run when payload fails to decode")
+	}
+}
+
+func TestServeHTTPSignatureMismatch(t *testing.T) {
+	secret := []byte("top-secret")
+	d := NewDispatcher(secret)
+	d.Register("signup", SignupHandlerFunc(func(context.Context, SignupEvent) error { return nil }))
+
+	body := []byte(`{"user_id":1,"name":"Ada","email":"ada@example.com"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook/signup", strings.NewReader(string(body)))
+	req.Header.Set(SignatureHeader, "sha256=deadbeef")
+	w := httptest.NewRecorder()
+
+	d.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestServeHTTPDispatchesBySegment(t *testing.T) {
+	secret := []byte("top-secret")
+	d := NewDispatcher(secret)
+
+	var got SignupEvent
+	d.Register("signup", SignupHandlerFunc(func(_ context.Context, e SignupEvent) error {
+		got = e
+		return nil
+	}))
+
+	body := []byte(`{"user_id":1,"name":"Ada","email":"ada@example.com"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhook/signup", strings.NewReader(string(body)))
+	req.Header.Set(SignatureHeader, sign(secret, body))
+	w := httptest.NewRecorder()
+
+	d.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got.Email != "ada@example.com" {
+		t.Fatalf("expected decoded event to reach handler, got %+v", got)
+	}
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SignupEvent is emitted when a new user signs up.
+type SignupEvent struct {
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+// UpgradeEvent is emitted when a user changes plan.
+type UpgradeEvent struct {
+	UserID int    `json:"user_id"`
+	Plan   string `json:"plan"`
+}
+
+// DeleteEvent is emitted when a user account is removed.
+type DeleteEvent struct {
+	UserID int `json:"user_id"`
+}
+
+// SignupHandlerFunc adapts a typed SignupEvent callback into a Handler.
+func SignupHandlerFunc(fn func(context.Context, SignupEvent) error) Handler {
+	return func(ctx context.Context, payload []byte) error {
+		var e SignupEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return err
+		}
+		return fn(ctx, e)
+	}
+}
+
+// UpgradeHandlerFunc adapts a typed UpgradeEvent callback into a Handler.
+func UpgradeHandlerFunc(fn func(context.Context, UpgradeEvent) error) Handler {
+	return func(ctx context.Context, payload []byte) error {
+		var e UpgradeEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return err
+		}
+		return fn(ctx, e)
+	}
+}
+
+// DeleteHandlerFunc adapts a typed DeleteEvent callback into a Handler.
+func DeleteHandlerFunc(fn func(context.Context, DeleteEvent) error) Handler {
+	return func(ctx context.Context, payload []byte) error {
+		var e DeleteEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			return err
+		}
+		return fn(ctx, e)
+	}
+}
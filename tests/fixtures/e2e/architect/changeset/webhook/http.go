@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request body, in
+// the form "sha256=<hex>".
+const SignatureHeader = "X-Webhook-Signature"
+
+// ErrInvalidSignature is returned when a request's signature does not match
+// the configured secret.
+var ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+// ServeHTTP implements http.Handler. It expects requests at
+// /webhook/{service}, taking the trailing path segment as the event type to
+// dispatch to, mirroring the go-neb convention of splitting the URL path and
+// using the last segment as the service key.
+func (d *Dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	eventType := segments[len(segments)-1]
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(d.secret) > 0 {
+		if err := d.verifySignature(body, r.Header.Get(SignatureHeader)); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if err := d.Dispatch(r.Context(), eventType, body); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifySignature checks the "sha256=<hex>" signature header against an
+// HMAC-SHA256 of body computed with the dispatcher's secret.
+func (d *Dispatcher) verifySignature(body []byte, signature string) error {
+	const prefix = "sha256="
+	if !strings.HasPrefix(signature, prefix) {
+		return ErrInvalidSignature
+	}
+
+	want, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, d.secret)
+	mac.Write(body)
+	got := mac.Sum(nil)
+
+	if !hmac.Equal(got, want) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
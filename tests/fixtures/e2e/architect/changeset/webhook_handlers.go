@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"nitpik/webhook"
+)
+
+// RegisterWebhookHandlers wires AppService's signup/upgrade/delete logic
+// into a webhook.Dispatcher.
+func RegisterWebhookHandlers(d *webhook.Dispatcher, s *AppService) {
+	d.Register("signup", webhook.SignupHandlerFunc(func(ctx context.Context, e webhook.SignupEvent) error {
+		s.DB.CreateUser(e.UserID, e.Name, e.Email)
+		return s.SendEmail(ctx, e.Email, "Welcome!", "Thanks for signing up.")
+	}))
+
+	d.Register("upgrade", webhook.UpgradeHandlerFunc(func(ctx context.Context, e webhook.UpgradeEvent) error {
+		s.DB.UpdatePlan(e.UserID, e.Plan)
+		s.Users.InvalidateUser(e.UserID)
+		user, err := s.GetUser(e.UserID)
+		if err != nil {
+			return err
+		}
+		return s.SendEmail(ctx, user.Email, "Plan Upgraded", "Your plan has been upgraded.")
+	}))
+
+	d.Register("delete", webhook.DeleteHandlerFunc(func(ctx context.Context, e webhook.DeleteEvent) error {
+		user, err := s.GetUser(e.UserID)
+		if err != nil {
+			return err
+		}
+		s.DB.DeleteUser(e.UserID)
+		s.Users.InvalidateUser(e.UserID)
+		return s.SendEmail(ctx, user.Email, "Account Deleted", "Your account has been deleted.")
+	}))
+}